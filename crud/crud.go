@@ -0,0 +1,271 @@
+package crud
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/dearcode/petrel/handler"
+	"github.com/dearcode/petrel/orm"
+)
+
+//defaultLimit list接口不带limit参数时的默认分页大小.
+const defaultLimit = 20
+
+var filterOps = map[string]string{
+	"eq":   "=",
+	"ne":   "!=",
+	"gt":   ">",
+	"lt":   "<",
+	"like": "like",
+}
+
+//Resource 把一个结构体类型注册为一张表的REST资源，它本身实现了http.Handler，
+//自动提供 GET/{table}、GET/{table}/{id}、POST/{table}、PUT/{table}/{id}、
+//DELETE/{table}/{id}，并基于 orm.Stmt 读写对应的表.
+type Resource struct {
+	table string
+	typ   reflect.Type
+	db    *sql.DB
+}
+
+//New 注册一个资源，typ是表对应的结构体（可以是指针），table是表名.
+func New(db *sql.DB, table string, typ interface{}) *Resource {
+	rt := reflect.TypeOf(typ)
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	return &Resource{
+		table: table,
+		typ:   rt,
+		db:    db,
+	}
+}
+
+//ServeHTTP 实现http.Handler，根据method和路径最后一段是否为id，分发到list/get/create/update/delete.
+func (r *Resource) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(req.URL.Path, "/"+r.table), "/")
+
+	var err error
+
+	switch {
+	case req.Method == http.MethodGet && id == "":
+		err = r.list(w, req)
+	case req.Method == http.MethodGet:
+		err = r.get(w, id)
+	case req.Method == http.MethodPost && id == "":
+		err = r.create(w, req)
+	case req.Method == http.MethodPut && id != "":
+		err = r.update(w, req, id)
+	case req.Method == http.MethodDelete && id != "":
+		err = r.delete(w, id)
+	default:
+		http.NotFound(w, req)
+		return
+	}
+
+	if err == nil {
+		return
+	}
+
+	if errors.Cause(err) == orm.ErrNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+//pkColumn 约定表里db_default:"auto"标注的字段为主键，跟orm.SQLInsert的自增列约定一致.
+func (r *Resource) pkColumn() (string, error) {
+	for i := 0; i < r.typ.NumField(); i++ {
+		f := r.typ.Field(i)
+		if f.Tag.Get("db_default") != "auto" {
+			continue
+		}
+		name := f.Tag.Get("db")
+		if name == "" {
+			name = orm.FieldEscape(f.Name)
+		}
+		return name, nil
+	}
+	return "", fmt.Errorf("%s: primary key not found, need a db_default:\"auto\" field", r.table)
+}
+
+func (r *Resource) stmt() *orm.Stmt {
+	return orm.NewStmt(r.db, r.table)
+}
+
+//columns 返回r.typ的所有db列名，用于对sort/filter里来自请求的字段名做白名单校验，
+//避免把查询串拼进sql时被注入.
+func (r *Resource) columns() map[string]bool {
+	cols := make(map[string]bool, r.typ.NumField())
+	for i := 0; i < r.typ.NumField(); i++ {
+		f := r.typ.Field(i)
+		if f.PkgPath != "" && !f.Anonymous { // unexported
+			continue
+		}
+		name := f.Tag.Get("db")
+		if name == "" {
+			name = orm.FieldEscape(f.Name)
+		}
+		cols[name] = true
+	}
+	return cols
+}
+
+func (r *Resource) get(w http.ResponseWriter, id string) error {
+	pk, err := r.pkColumn()
+	if err != nil {
+		return err
+	}
+
+	result := reflect.New(r.typ).Interface()
+	if err := r.stmt().Where(fmt.Sprintf("%s=?", pk), id).Query(result); err != nil {
+		return errors.Trace(err)
+	}
+	return writeJSON(w, result)
+}
+
+func (r *Resource) list(w http.ResponseWriter, req *http.Request) error {
+	q := req.URL.Query()
+
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	cols := r.columns()
+
+	stmt := r.stmt().Limit(limit).Offset((page - 1) * limit)
+
+	if sort := q.Get("sort"); sort != "" {
+		if !cols[sort] {
+			return fmt.Errorf("invalid sort:%v", sort)
+		}
+		stmt.Sort(sort)
+
+		if order := q.Get("order"); order != "" {
+			switch strings.ToLower(order) {
+			case "asc", "desc":
+			default:
+				return fmt.Errorf("invalid order:%v", order)
+			}
+			stmt.Order(order)
+		}
+	}
+
+	if filter := q.Get("filter"); filter != "" {
+		where, args, err := parseFilter(filter, cols)
+		if err != nil {
+			return err
+		}
+		stmt.Where(where, args...)
+	}
+
+	result := reflect.New(reflect.SliceOf(r.typ)).Interface()
+	if err := stmt.Query(result); err != nil && errors.Cause(err) != orm.ErrNotFound {
+		return errors.Trace(err)
+	}
+	return writeJSON(w, result)
+}
+
+func (r *Resource) create(w http.ResponseWriter, req *http.Request) error {
+	data := reflect.New(r.typ).Interface()
+	if err := handler.UnmarshalJSON(req, data); err != nil {
+		return err
+	}
+
+	id, err := r.stmt().Insert(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	return writeJSON(w, map[string]int64{"id": id})
+}
+
+func (r *Resource) update(w http.ResponseWriter, req *http.Request, id string) error {
+	pk, err := r.pkColumn()
+	if err != nil {
+		return err
+	}
+
+	data := reflect.New(r.typ).Interface()
+	if err := handler.UnmarshalJSON(req, data); err != nil {
+		return err
+	}
+
+	n, err := r.stmt().Where(fmt.Sprintf("%s=?", pk), id).Update(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	return writeJSON(w, map[string]int64{"affected": n})
+}
+
+func (r *Resource) delete(w http.ResponseWriter, id string) error {
+	pk, err := r.pkColumn()
+	if err != nil {
+		return err
+	}
+
+	n, err := r.stmt().Where(fmt.Sprintf("%s=?", pk), id).Delete()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	return writeJSON(w, map[string]int64{"affected": n})
+}
+
+//parseFilter 解析 field:op:value 形式的过滤条件，op属于eq/ne/gt/lt/like/in，
+//field必须在cols白名单里，否则拒绝，避免把请求里的字段名当sql标识符拼接.
+func parseFilter(filter string, cols map[string]bool) (string, []interface{}, error) {
+	parts := strings.SplitN(filter, ":", 3)
+	if len(parts) != 3 {
+		return "", nil, fmt.Errorf("invalid filter:%v", filter)
+	}
+	field, op, value := parts[0], parts[1], parts[2]
+
+	if !cols[field] {
+		return "", nil, fmt.Errorf("invalid filter field:%v", field)
+	}
+
+	if op == "in" {
+		vs := strings.Split(value, ",")
+		ps := make([]string, len(vs))
+		args := make([]interface{}, len(vs))
+		for i, v := range vs {
+			ps[i] = "?"
+			args[i] = v
+		}
+		return fmt.Sprintf("%s in (%s)", field, strings.Join(ps, ",")), args, nil
+	}
+
+	sym, ok := filterOps[op]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid filter op:%v", op)
+	}
+
+	if op == "like" {
+		return fmt.Sprintf("%s like ?", field), []interface{}{"%" + value + "%"}, nil
+	}
+
+	return fmt.Sprintf("%s%s?", field, sym), []interface{}{value}, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,85 @@
+package crud
+
+import (
+	"reflect"
+	"testing"
+)
+
+type crudTestItem struct {
+	ID   int64  `db:"id" db_default:"auto"`
+	Name string `db:"name"`
+}
+
+func testColumns() map[string]bool {
+	return New(nil, "items", crudTestItem{}).columns()
+}
+
+func TestResourceColumns(t *testing.T) {
+	want := map[string]bool{"id": true, "name": true}
+	if got := testColumns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("columns=%v want=%v", got, want)
+	}
+}
+
+func TestParseFilterOps(t *testing.T) {
+	cols := testColumns()
+
+	cases := []struct {
+		name      string
+		filter    string
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{"eq", "id:eq:1", "id=?", []interface{}{"1"}},
+		{"ne", "name:ne:bob", "name!=?", []interface{}{"bob"}},
+		{"gt", "id:gt:10", "id>?", []interface{}{"10"}},
+		{"lt", "id:lt:10", "id<?", []interface{}{"10"}},
+		{"like", "name:like:bo", "name like ?", []interface{}{"%bo%"}},
+		{"in", "id:in:1,2,3", "id in (?,?,?)", []interface{}{"1", "2", "3"}},
+	}
+
+	for _, c := range cases {
+		where, args, err := parseFilter(c.filter, cols)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		if where != c.wantWhere {
+			t.Errorf("%s: where=%q want=%q", c.name, where, c.wantWhere)
+		}
+		if !reflect.DeepEqual(args, c.wantArgs) {
+			t.Errorf("%s: args=%v want=%v", c.name, args, c.wantArgs)
+		}
+	}
+}
+
+func TestParseFilterRejectsUnknownField(t *testing.T) {
+	cols := testColumns()
+
+	cases := []string{
+		"id);drop table items--:eq:1",
+		"1 or 1=1:eq:1",
+		"deleted_at:eq:1",
+	}
+
+	for _, filter := range cases {
+		if _, _, err := parseFilter(filter, cols); err == nil {
+			t.Errorf("filter %q: expected error, got none", filter)
+		}
+	}
+}
+
+func TestParseFilterRejectsBadFormatOrOp(t *testing.T) {
+	cols := testColumns()
+
+	cases := []string{
+		"id:eq",     // missing value
+		"id:drop:1", // unknown op
+		"",          // empty
+	}
+
+	for _, filter := range cases {
+		if _, _, err := parseFilter(filter, cols); err == nil {
+			t.Errorf("filter %q: expected error, got none", filter)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/davygeek/log"
+
+	"github.com/dearcode/petrel/validation"
+)
+
+// Parse 根据result每个字段上的param/query/header/form/json tag，自动从对应的来源
+// （查询串、请求头、表单、body）取值并填充，一次调用即可替代分别调用
+// ParseFormVars/ParseJSONVars/ParseHeaderVars/ParseURLVars，最后统一执行一次
+// validation.Validation{}.Valid. result 必须是一个结构体指针.
+// param tag跟ParseURLVars一样通过req.FormValue取值（查询串和表单），不解析URL路径本身.
+func Parse(req *http.Request, result interface{}) error {
+	rt := reflect.TypeOf(result)
+	rv := reflect.ValueOf(result)
+
+	if rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("result type must be struct ptr, recv:%v", rt.Kind())
+	}
+
+	rt = rt.Elem()
+	rv = rv.Elem()
+
+	var needForm, needJSON bool
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.Tag.Get("form") != "" || f.Tag.Get("query") != "" {
+			needForm = true
+		}
+		if f.Tag.Get("json") != "" {
+			needJSON = true
+		}
+	}
+
+	if needForm {
+		if err := req.ParseForm(); err != nil {
+			return err
+		}
+	}
+
+	if needJSON {
+		if err := UnmarshalJSON(req, result); err != nil {
+			return err
+		}
+		//body是整体反序列化的，取最新的值.
+		rv = reflect.ValueOf(result).Elem()
+	}
+
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+
+		key := f.Tag.Get("query")
+		if key == "" {
+			key = f.Tag.Get("form")
+		}
+		if key != "" {
+			//query/form可能有重复key.
+			if f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.String {
+				rv.Field(i).Set(reflect.ValueOf(req.Form[key]))
+				continue
+			}
+			if err := setFieldValue(rv.Field(i), f, key, req.FormValue(key)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if key = f.Tag.Get("param"); key != "" {
+			if err := setFieldValue(rv.Field(i), f, key, req.FormValue(key)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if key = f.Tag.Get("header"); key != "" {
+			if err := setFieldValue(rv.Field(i), f, key, req.Header.Get(key)); err != nil {
+				return err
+			}
+			continue
+		}
+	}
+
+	log.Debugf("request vars:%v", result)
+	valid := validation.Validation{}
+	_, err := valid.Valid(result)
+	return err
+}
+
+// Bind 是Parse的别名，命名上贴近echo等框架里c.Bind的习惯用法.
+func Bind(req *http.Request, result interface{}) error {
+	return Parse(req, result)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type bindOverrideTest struct {
+	Name string   `json:"name" header:"X-Name"`
+	Tags []string `query:"tag"`
+}
+
+//TestParseHeaderOverridesJSON 验证同一字段既有json tag又有header/query/param tag时，
+//body先整体反序列化，随后per-field的header/query/param会覆盖json填进去的值.
+func TestParseHeaderOverridesJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/?tag=a&tag=b", strings.NewReader(`{"name":"from-json"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Name", "from-header")
+
+	var result bindOverrideTest
+	if err := Parse(req, &result); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if result.Name != "from-header" {
+		t.Errorf("Name=%q want=from-header (header should win over json body)", result.Name)
+	}
+	if !reflect.DeepEqual(result.Tags, []string{"a", "b"}) {
+		t.Errorf("Tags=%v want=[a b] (repeated query values)", result.Tags)
+	}
+}
+
+type bindFormSliceTest struct {
+	Tags []string `form:"tag"`
+}
+
+func TestParseRepeatedFormValues(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?tag=x&tag=y&tag=z", nil)
+
+	var result bindFormSliceTest
+	if err := Parse(req, &result); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	want := []string{"x", "y", "z"}
+	if !reflect.DeepEqual(result.Tags, want) {
+		t.Errorf("Tags=%v want=%v", result.Tags, want)
+	}
+}
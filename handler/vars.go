@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/davygeek/log"
 
@@ -36,6 +37,18 @@ func UnmarshalForm(req *http.Request, postion VariablePostion, result interface{
 		if key == "" {
 			key = f.Name
 		}
+
+		//重复的form/url参数，取全部值，header不支持重复.
+		if postion != HEADER && f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.String {
+			if req.Form == nil {
+				if err := req.ParseForm(); err != nil {
+					return err
+				}
+			}
+			rv.Field(i).Set(reflect.ValueOf(req.Form[key]))
+			continue
+		}
+
 		var val string
 
 		switch postion {
@@ -45,20 +58,77 @@ func UnmarshalForm(req *http.Request, postion VariablePostion, result interface{
 			val = req.Header.Get(key)
 		}
 
-		switch f.Type.Kind() {
-		case reflect.Int, reflect.Int64:
-			vi, err := strconv.ParseInt(val, 10, 64)
-			if err != nil {
-				//不需要验证的key就不返回错误了
-				if f.Tag.Get("valid") == "" {
-					break
-				}
-				return fmt.Errorf("key:%v value:%v format error", key, val)
+		if err := setFieldValue(rv.Field(i), f, key, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//setFieldValue 按字段类型把字符串值转换后写入rv，支持int/int64/uint/uint64/bool/float64/string，
+//以及通过format tag指定时间格式的time.Time. 转换失败时，没有加valid tag的字段跟老版本行为一致不报错.
+func setFieldValue(rv reflect.Value, f reflect.StructField, key, val string) error {
+	switch {
+	case rv.Kind() == reflect.Int || rv.Kind() == reflect.Int8 || rv.Kind() == reflect.Int16 || rv.Kind() == reflect.Int32 || rv.Kind() == reflect.Int64:
+		vi, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			if f.Tag.Get("valid") == "" {
+				return nil
+			}
+			return fmt.Errorf("key:%v value:%v format error", key, val)
+		}
+		rv.SetInt(vi)
+	case rv.Kind() == reflect.Uint || rv.Kind() == reflect.Uint8 || rv.Kind() == reflect.Uint16 || rv.Kind() == reflect.Uint32 || rv.Kind() == reflect.Uint64:
+		vu, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			if f.Tag.Get("valid") == "" {
+				return nil
+			}
+			return fmt.Errorf("key:%v value:%v format error", key, val)
+		}
+		rv.SetUint(vu)
+	case rv.Kind() == reflect.Bool:
+		if val == "" {
+			return nil
+		}
+		vb, err := strconv.ParseBool(val)
+		if err != nil {
+			if f.Tag.Get("valid") == "" {
+				return nil
+			}
+			return fmt.Errorf("key:%v value:%v format error", key, val)
+		}
+		rv.SetBool(vb)
+	case rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64:
+		if val == "" {
+			return nil
+		}
+		vf, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			if f.Tag.Get("valid") == "" {
+				return nil
+			}
+			return fmt.Errorf("key:%v value:%v format error", key, val)
+		}
+		rv.SetFloat(vf)
+	case rv.Kind() == reflect.String:
+		rv.SetString(val)
+	case rv.Type() == reflect.TypeOf(time.Time{}):
+		if val == "" {
+			return nil
+		}
+		format := f.Tag.Get("format")
+		if format == "" {
+			format = time.RFC3339
+		}
+		vt, err := time.Parse(format, val)
+		if err != nil {
+			if f.Tag.Get("valid") == "" {
+				return nil
 			}
-			rv.Field(i).SetInt(vi)
-		case reflect.String:
-			rv.Field(i).SetString(val)
+			return fmt.Errorf("key:%v value:%v format error", key, val)
 		}
+		rv.Set(reflect.ValueOf(vt))
 	}
 	return nil
 }
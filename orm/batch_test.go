@@ -0,0 +1,61 @@
+package orm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testUser struct {
+	ID   int64  `db:"id" db_default:"auto"`
+	Name string `db:"name"`
+}
+
+func TestSQLInsertBatch(t *testing.T) {
+	rt := reflect.TypeOf(testUser{})
+	rows := []reflect.Value{
+		reflect.ValueOf(testUser{ID: 1, Name: "a"}),
+		reflect.ValueOf(testUser{ID: 2, Name: "b"}),
+	}
+
+	s := NewStmt(nil, "users")
+	sql, refs := s.SQLInsertBatch(rt, rows)
+
+	wantSQL := "insert into `users` (`name`) values (?), (?)"
+	if sql != wantSQL {
+		t.Errorf("sql=%q want=%q", sql, wantSQL)
+	}
+	wantArgs := []interface{}{"a", "b"}
+	if !reflect.DeepEqual(refs, wantArgs) {
+		t.Errorf("args=%v want=%v", refs, wantArgs)
+	}
+}
+
+func TestSQLUpsertConflictTarget(t *testing.T) {
+	rt := reflect.TypeOf(testUser{})
+	rv := reflect.ValueOf(testUser{ID: 1, Name: "a"})
+
+	t.Run("mysql ignores conflict target", func(t *testing.T) {
+		s := NewStmtWithDialect(nil, "users", MySQLDialect)
+		sql, _ := s.SQLUpsert(rt, rv, nil)
+		if want := "on duplicate key update `name`=values(`name`)"; !strings.Contains(sql, want) {
+			t.Errorf("sql=%q want substring %q", sql, want)
+		}
+	})
+
+	t.Run("postgres derives conflict target from db_default auto", func(t *testing.T) {
+		s := NewStmtWithDialect(nil, "users", PostgresDialect)
+		sql, _ := s.SQLUpsert(rt, rv, nil)
+		if want := `on conflict ("id") do update set "name"=excluded."name"`; !strings.Contains(sql, want) {
+			t.Errorf("sql=%q want substring %q", sql, want)
+		}
+	})
+
+	t.Run("sqlite honors explicit OnConflict", func(t *testing.T) {
+		s := NewStmtWithDialect(nil, "users", SQLiteDialect).OnConflict("name")
+		sql, _ := s.SQLUpsert(rt, rv, nil)
+		if want := `on conflict ("name") do update set "name"=excluded."name"`; !strings.Contains(sql, want) {
+			t.Errorf("sql=%q want substring %q", sql, want)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Dialect 描述不同数据库在标识符引用、占位符、分页和upsert语法上的差异，
+//让同一个petrel服务可以通过NewStmtWithDialect切换不同的后端.
+type Dialect interface {
+	//QuoteIdent 给表名、列名加上当前方言的引用符.
+	QuoteIdent(name string) string
+	//Placeholder 返回第i个（从1开始计数）绑定参数的占位符.
+	Placeholder(i int) string
+	//LimitOffset 渲染limit/offset子句，包含前导空格，limit<=0时不会被调用.
+	LimitOffset(limit, offset int) string
+	//UpsertClause 渲染insert遇到唯一键冲突时的更新子句，conflictCols是冲突判断依据的
+	//唯一键/主键列，cols是需要更新的列名.
+	UpsertClause(conflictCols, cols []string) string
+	//NeedsConflictTarget 为true表示UpsertClause依赖conflictCols渲染语法（Postgres/SQLite
+	//的on conflict (...)），调用方必须能提供一个非空的conflictCols，否则应该直接报错；
+	//MySQL的on duplicate key update靠表自身的唯一键约束，不需要.
+	NeedsConflictTarget() bool
+}
+
+type mysqlDialect struct{}
+
+//QuoteIdent 用反引号包裹标识符.
+func (mysqlDialect) QuoteIdent(name string) string {
+	return "`" + name + "`"
+}
+
+//Placeholder MySQL的占位符固定是?.
+func (mysqlDialect) Placeholder(int) string {
+	return "?"
+}
+
+//LimitOffset MySQL用 limit offset,count 的形式.
+func (mysqlDialect) LimitOffset(limit, offset int) string {
+	if offset > 0 {
+		return fmt.Sprintf(" limit %d,%d", offset, limit)
+	}
+	return fmt.Sprintf(" limit %d", limit)
+}
+
+//UpsertClause MySQL用 on duplicate key update，不需要冲突目标列.
+func (d mysqlDialect) UpsertClause(_, cols []string) string {
+	parts := make([]string, len(cols))
+	for i, c := range cols {
+		q := d.QuoteIdent(c)
+		parts[i] = fmt.Sprintf("%s=values(%s)", q, q)
+	}
+	return "on duplicate key update " + strings.Join(parts, ", ")
+}
+
+//NeedsConflictTarget MySQL靠表的唯一键约束自己判断冲突，不需要显式列出.
+func (mysqlDialect) NeedsConflictTarget() bool {
+	return false
+}
+
+type postgresDialect struct{}
+
+//QuoteIdent Postgres用双引号包裹标识符.
+func (postgresDialect) QuoteIdent(name string) string {
+	return `"` + name + `"`
+}
+
+//Placeholder Postgres的占位符是$1,$2,...
+func (postgresDialect) Placeholder(i int) string {
+	return fmt.Sprintf("$%d", i)
+}
+
+//LimitOffset Postgres用 limit n offset m 的形式.
+func (postgresDialect) LimitOffset(limit, offset int) string {
+	s := fmt.Sprintf(" limit %d", limit)
+	if offset > 0 {
+		s += fmt.Sprintf(" offset %d", offset)
+	}
+	return s
+}
+
+//UpsertClause Postgres要求on conflict带上冲突判断列，否则是语法错误.
+func (d postgresDialect) UpsertClause(conflictCols, cols []string) string {
+	parts := make([]string, len(cols))
+	for i, c := range cols {
+		q := d.QuoteIdent(c)
+		parts[i] = fmt.Sprintf("%s=excluded.%s", q, q)
+	}
+	targets := make([]string, len(conflictCols))
+	for i, c := range conflictCols {
+		targets[i] = d.QuoteIdent(c)
+	}
+	return fmt.Sprintf("on conflict (%s) do update set %s", strings.Join(targets, ", "), strings.Join(parts, ", "))
+}
+
+//NeedsConflictTarget Postgres的on conflict do update必须带冲突判断列.
+func (postgresDialect) NeedsConflictTarget() bool {
+	return true
+}
+
+type sqliteDialect struct{}
+
+//QuoteIdent SQLite也用双引号包裹标识符.
+func (sqliteDialect) QuoteIdent(name string) string {
+	return `"` + name + `"`
+}
+
+//Placeholder SQLite的占位符跟MySQL一样是?.
+func (sqliteDialect) Placeholder(int) string {
+	return "?"
+}
+
+//LimitOffset SQLite用 limit n offset m 的形式.
+func (sqliteDialect) LimitOffset(limit, offset int) string {
+	s := fmt.Sprintf(" limit %d", limit)
+	if offset > 0 {
+		s += fmt.Sprintf(" offset %d", offset)
+	}
+	return s
+}
+
+//UpsertClause SQLite跟Postgres一样，on conflict需要带上冲突判断列.
+func (d sqliteDialect) UpsertClause(conflictCols, cols []string) string {
+	parts := make([]string, len(cols))
+	for i, c := range cols {
+		q := d.QuoteIdent(c)
+		parts[i] = fmt.Sprintf("%s=excluded.%s", q, q)
+	}
+	targets := make([]string, len(conflictCols))
+	for i, c := range conflictCols {
+		targets[i] = d.QuoteIdent(c)
+	}
+	return fmt.Sprintf("on conflict (%s) do update set %s", strings.Join(targets, ", "), strings.Join(parts, ", "))
+}
+
+//NeedsConflictTarget SQLite跟Postgres一样，on conflict do update必须带冲突判断列.
+func (sqliteDialect) NeedsConflictTarget() bool {
+	return true
+}
+
+var (
+	//MySQLDialect MySQL语法，NewStmt默认使用.
+	MySQLDialect Dialect = mysqlDialect{}
+	//PostgresDialect Postgres语法，配合lib/pq等driver使用.
+	PostgresDialect Dialect = postgresDialect{}
+	//SQLiteDialect SQLite语法，配合modernc.org/sqlite等driver使用.
+	SQLiteDialect Dialect = sqliteDialect{}
+)
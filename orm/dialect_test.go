@@ -0,0 +1,98 @@
+package orm
+
+import "testing"
+
+func TestLimitOffset(t *testing.T) {
+	cases := []struct {
+		dialect Dialect
+		limit   int
+		offset  int
+		want    string
+	}{
+		{MySQLDialect, 10, 0, " limit 10"},
+		{MySQLDialect, 10, 20, " limit 20,10"},
+		{PostgresDialect, 10, 0, " limit 10"},
+		{PostgresDialect, 10, 20, " limit 10 offset 20"},
+		{SQLiteDialect, 10, 20, " limit 10 offset 20"},
+	}
+
+	for _, c := range cases {
+		got := c.dialect.LimitOffset(c.limit, c.offset)
+		if got != c.want {
+			t.Errorf("LimitOffset(%d,%d)=%q want=%q", c.limit, c.offset, got, c.want)
+		}
+	}
+}
+
+func TestUpsertClauseConflictTarget(t *testing.T) {
+	cases := []struct {
+		name         string
+		dialect      Dialect
+		conflictCols []string
+		cols         []string
+		want         string
+	}{
+		{
+			name:         "mysql",
+			dialect:      MySQLDialect,
+			conflictCols: []string{"id"},
+			cols:         []string{"name"},
+			want:         "on duplicate key update `name`=values(`name`)",
+		},
+		{
+			name:         "postgres",
+			dialect:      PostgresDialect,
+			conflictCols: []string{"id"},
+			cols:         []string{"name"},
+			want:         `on conflict ("id") do update set "name"=excluded."name"`,
+		},
+		{
+			name:         "sqlite",
+			dialect:      SQLiteDialect,
+			conflictCols: []string{"id"},
+			cols:         []string{"name"},
+			want:         `on conflict ("id") do update set "name"=excluded."name"`,
+		},
+	}
+
+	for _, c := range cases {
+		got := c.dialect.UpsertClause(c.conflictCols, c.cols)
+		if got != c.want {
+			t.Errorf("%s: UpsertClause=%q want=%q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestNeedsConflictTarget(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect Dialect
+		want    bool
+	}{
+		{"mysql", MySQLDialect, false},
+		{"postgres", PostgresDialect, true},
+		{"sqlite", SQLiteDialect, true},
+	}
+
+	for _, c := range cases {
+		if got := c.dialect.NeedsConflictTarget(); got != c.want {
+			t.Errorf("%s: NeedsConflictTarget()=%v want=%v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestRewritePlaceholders(t *testing.T) {
+	s := NewStmtWithDialect(nil, "users", PostgresDialect)
+	got := s.rewritePlaceholders("select * from users where id=? and name=?")
+	want := "select * from users where id=$1 and name=$2"
+	if got != want {
+		t.Errorf("rewritePlaceholders=%q want=%q", got, want)
+	}
+
+	s = NewStmt(nil, "users")
+	got = s.rewritePlaceholders("select * from users where id=?")
+	want = "select * from users where id=?"
+	if got != want {
+		t.Errorf("rewritePlaceholders=%q want=%q", got, want)
+	}
+}
@@ -0,0 +1,39 @@
+package orm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStmtIn(t *testing.T) {
+	cases := []struct {
+		name      string
+		values    []interface{}
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "with values",
+			values:    []interface{}{1, 2, 3},
+			wantWhere: "id in (?,?,?)",
+			wantArgs:  []interface{}{1, 2, 3},
+		},
+		{
+			name:      "empty values",
+			values:    nil,
+			wantWhere: "1=0",
+			wantArgs:  nil,
+		},
+	}
+
+	for _, c := range cases {
+		s := NewStmt(nil, "users")
+		s.In("id", c.values...)
+		if s.where != c.wantWhere {
+			t.Errorf("%s: where=%q want=%q", c.name, s.where, c.wantWhere)
+		}
+		if !reflect.DeepEqual(s.whereArgs, c.wantArgs) {
+			t.Errorf("%s: args=%v want=%v", c.name, s.whereArgs, c.wantArgs)
+		}
+	}
+}
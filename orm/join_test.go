@@ -0,0 +1,105 @@
+package orm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStmtExplicitJoins(t *testing.T) {
+	s := NewStmt(nil, "orders")
+	s.LeftJoin("users", "users.id=orders.user_id").InnerJoin("products", "products.id=orders.product_id")
+
+	want := []string{
+		" left join users on users.id=orders.user_id",
+		" inner join products on products.id=orders.product_id",
+	}
+	if !reflect.DeepEqual(s.joins, want) {
+		t.Errorf("joins=%v want=%v", s.joins, want)
+	}
+}
+
+type joinTestUser struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+type joinTestOrder struct {
+	ID           int64 `db:"id"`
+	joinTestUser `join:"users.id=orders.user_id"`
+	Amount       float64 `db:"amount"`
+}
+
+func TestSQLQueryWithJoinTag(t *testing.T) {
+	rt := reflect.TypeOf(joinTestOrder{})
+
+	s := NewStmt(nil, "orders")
+	s.Where("orders.id=?", 1)
+
+	sql, args := s.SQLQuery(rt)
+
+	want := "select orders.id, users.id, users.name, orders.amount from orders" +
+		" left join users on users.id=orders.user_id where orders.id=?"
+	if sql != want {
+		t.Errorf("sql=%q want=%q", sql, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1}) {
+		t.Errorf("args=%v want=[1]", args)
+	}
+}
+
+func TestSQLQueryWithJoinTagDoesNotAccumulate(t *testing.T) {
+	rt := reflect.TypeOf(joinTestOrder{})
+
+	s := NewStmt(nil, "orders")
+	first, _ := s.SQLQuery(rt)
+	second, _ := s.SQLQuery(rt)
+
+	if first != second {
+		t.Errorf("repeated SQLQuery calls diverged: first=%q second=%q", first, second)
+	}
+	if len(s.joins) != 0 {
+		t.Errorf("tag-derived join leaked into s.joins: %v", s.joins)
+	}
+}
+
+func TestCollectRefsWithJoinTag(t *testing.T) {
+	rt := reflect.TypeOf(joinTestOrder{})
+	rv := reflect.ValueOf(&joinTestOrder{
+		ID:           1,
+		joinTestUser: joinTestUser{ID: 2, Name: "bob"},
+		Amount:       9.5,
+	}).Elem()
+
+	refs := collectRefs(rv, rt)
+	if len(refs) != 4 {
+		t.Fatalf("refs len=%d want=4", len(refs))
+	}
+
+	if got := *refs[0].(*int64); got != 1 {
+		t.Errorf("refs[0]=%d want=1", got)
+	}
+	if got := *refs[1].(*int64); got != 2 {
+		t.Errorf("refs[1]=%d want=2", got)
+	}
+	if got := *refs[2].(*string); got != "bob" {
+		t.Errorf("refs[2]=%q want=bob", got)
+	}
+	if got := *refs[3].(*float64); got != 9.5 {
+		t.Errorf("refs[3]=%v want=9.5", got)
+	}
+}
+
+func TestSQLCountRendersExplicitJoins(t *testing.T) {
+	s := NewStmt(nil, "orders")
+	s.LeftJoin("users", "users.id=orders.user_id").Where("users.name=?", "bob")
+
+	sql, args := s.SQLCount()
+
+	want := "select count(*) from orders left join users on users.id=orders.user_id where users.name=?"
+	if sql != want {
+		t.Errorf("sql=%q want=%q", sql, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"bob"}) {
+		t.Errorf("args=%v want=[bob]", args)
+	}
+}
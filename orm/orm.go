@@ -2,10 +2,12 @@ package orm
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/davygeek/log"
@@ -19,34 +21,144 @@ var (
 
 //Stmt db stmt.
 type Stmt struct {
-	table  string
-	where  string
-	sort   string
-	order  string
-	group  string
-	offset int
-	limit  int
-	db     *sql.DB
+	table        string
+	joins        []string
+	where        string
+	whereArgs    []interface{}
+	sort         string
+	order        string
+	group        string
+	offset       int
+	limit        int
+	conflictCols []string
+	dialect      Dialect
+	db           *sql.DB
+	tx           *sql.Tx
+	ctx          context.Context
 }
 
-//NewStmt new db stmt.
+//NewStmt new db stmt，默认使用MySQLDialect.
 func NewStmt(db *sql.DB, table string) *Stmt {
+	return NewStmtWithDialect(db, table, MySQLDialect)
+}
+
+//NewStmtWithDialect 创建指定Dialect的db stmt，用于MySQL以外的后端，比如配合lib/pq的
+//PostgresDialect，或者配合modernc.org/sqlite的SQLiteDialect.
+func NewStmtWithDialect(db *sql.DB, table string, dialect Dialect) *Stmt {
 	return &Stmt{
-		table: table,
-		db:    db,
+		table:   table,
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+//NewStmtTx 在事务tx上创建db stmt，用于需要多个ORM调用共同提交或回滚的场景，默认使用MySQLDialect.
+func NewStmtTx(tx *sql.Tx, table string) *Stmt {
+	return NewStmtTxWithDialect(tx, table, MySQLDialect)
+}
+
+//NewStmtTxWithDialect 在事务tx上创建指定Dialect的db stmt，用于Postgres/SQLite等
+//非MySQL后端也需要事务支持的场景.
+func NewStmtTxWithDialect(tx *sql.Tx, table string, dialect Dialect) *Stmt {
+	return &Stmt{
+		table:   table,
+		tx:      tx,
+		dialect: dialect,
+	}
+}
+
+//WithContext 绑定ctx，后续的Query/Count/Insert/Update都会带上此ctx，用于支持超时和取消.
+func (s *Stmt) WithContext(ctx context.Context) *Stmt {
+	s.ctx = ctx
+	return s
+}
+
+//context 返回当前绑定的ctx，未设置时使用context.Background().
+func (s *Stmt) context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+	return context.Background()
+}
+
+//queryContext 优先使用tx执行查询，没有tx时退回db.
+func (s *Stmt) queryContext(query string, args ...interface{}) (*sql.Rows, error) {
+	if s.tx != nil {
+		return s.tx.QueryContext(s.context(), query, args...)
+	}
+	return s.db.QueryContext(s.context(), query, args...)
+}
+
+//execContext 优先使用tx执行语句，没有tx时退回db.
+func (s *Stmt) execContext(query string, args ...interface{}) (sql.Result, error) {
+	if s.tx != nil {
+		return s.tx.ExecContext(s.context(), query, args...)
 	}
+	return s.db.ExecContext(s.context(), query, args...)
 }
 
-//Where 添加查询条件
+//Where 添加查询条件，f 中使用 ? 占位符，args 作为绑定参数传给数据库，不会拼接进最终的SQL中，避免注入风险.
 func (s *Stmt) Where(f string, args ...interface{}) *Stmt {
+	s.where = f
+	s.whereArgs = args
+	return s
+}
+
+//WhereRaw 跟老版本Where行为一致，直接用fmt.Sprintf把参数拼接进条件里，存在注入风险，仅用于兼容老的调用方式，新代码请使用Where.
+func (s *Stmt) WhereRaw(f string, args ...interface{}) *Stmt {
 	if len(args) > 0 {
 		s.where = fmt.Sprintf(f, args...)
 	} else {
 		s.where = f
 	}
+	s.whereArgs = nil
+	return s
+}
+
+//In 添加 col in (?, ?, ...) 条件.
+func (s *Stmt) In(col string, values ...interface{}) *Stmt {
+	if len(values) == 0 {
+		//col in ()在MySQL/Postgres/SQLite里都是非法SQL，空集合直接恒为假.
+		return s.Where("1=0")
+	}
+
+	ps := make([]string, len(values))
+	for i := range values {
+		ps[i] = "?"
+	}
+	return s.Where(fmt.Sprintf("%s in (%s)", col, strings.Join(ps, ",")), values...)
+}
+
+//Between 添加 col between ? and ? 条件.
+func (s *Stmt) Between(col string, lo, hi interface{}) *Stmt {
+	return s.Where(fmt.Sprintf("%s between ? and ?", col), lo, hi)
+}
+
+//IsNull 添加 col is null / col is not null 条件.
+func (s *Stmt) IsNull(col string, isNull bool) *Stmt {
+	if isNull {
+		return s.Where(fmt.Sprintf("%s is null", col))
+	}
+	return s.Where(fmt.Sprintf("%s is not null", col))
+}
+
+//Join 添加一个join子句，kind为"left"/"inner"/"right"等，table是关联表名，on是关联条件，
+//渲染在from子句之后. 同一个Stmt可以多次调用以关联多张表.
+func (s *Stmt) Join(kind, table, on string) *Stmt {
+	s.joins = append(s.joins, fmt.Sprintf(" %s join %s on %s", kind, table, on))
 	return s
 }
 
+//LeftJoin 添加left join.
+func (s *Stmt) LeftJoin(table, on string) *Stmt {
+	return s.Join("left", table, on)
+}
+
+//InnerJoin 添加inner join.
+func (s *Stmt) InnerJoin(table, on string) *Stmt {
+	return s.Join("inner", table, on)
+}
+
 //Sort 添加sort
 func (s *Stmt) Sort(sort string) *Stmt {
 	s.sort = sort
@@ -77,11 +189,18 @@ func (s *Stmt) Limit(limit int) *Stmt {
 	return s
 }
 
+//OnConflict 显式指定Upsert冲突判断所依据的列（唯一键或主键），Postgres/SQLite的
+//on conflict (...)子句需要它。不调用时，SQLUpsert会退回使用db_default:"auto"标注的列.
+func (s *Stmt) OnConflict(cols ...string) *Stmt {
+	s.conflictCols = cols
+	return s
+}
+
 //SQLQueryBuilder build sql query.
-func (s *Stmt) SQLQueryBuilder(result interface{}) (string, error) {
+func (s *Stmt) SQLQueryBuilder(result interface{}) (string, []interface{}, error) {
 	rt := reflect.TypeOf(result)
 	if rt.Kind() != reflect.Ptr {
-		return "", fmt.Errorf("result type must be ptr, recv:%v", rt.Kind())
+		return "", nil, fmt.Errorf("result type must be ptr, recv:%v", rt.Kind())
 	}
 
 	//ptr
@@ -95,10 +214,11 @@ func (s *Stmt) SQLQueryBuilder(result interface{}) (string, error) {
 
 	//empty struct
 	if rt.NumField() == 0 {
-		return "", fmt.Errorf("result not found field")
+		return "", nil, fmt.Errorf("result not found field")
 	}
 
-	return s.SQLQuery(rt), nil
+	sql, args := s.SQLQuery(rt)
+	return sql, args, nil
 }
 
 //SQLCondition where, order, limit
@@ -119,55 +239,136 @@ func (s *Stmt) SQLCondition(bs *bytes.Buffer) {
 	}
 
 	if s.limit > 0 {
-		bs.WriteString(" limit ")
-		if s.offset > 0 {
-			fmt.Fprintf(bs, "%d,", s.offset)
+		bs.WriteString(s.dialect.LimitOffset(s.limit, s.offset))
+	}
+}
+
+//rewritePlaceholders 把SQL里的?占位符改写成当前dialect的风格，比如Postgres的$1,$2.
+func (s *Stmt) rewritePlaceholders(sql string) string {
+	if s.dialect.Placeholder(1) == "?" {
+		return sql
+	}
+
+	var bs strings.Builder
+	n := 0
+	for _, r := range sql {
+		if r != '?' {
+			bs.WriteRune(r)
+			continue
 		}
-		fmt.Fprintf(bs, "%d", s.limit)
+		n++
+		bs.WriteString(s.dialect.Placeholder(n))
 	}
+	return bs.String()
 }
 
-// SQLCount 根据条件及结构生成查询sql
-func (s *Stmt) SQLCount() string {
+// SQLCount 根据条件生成count查询sql，返回sql及where中绑定的参数
+func (s *Stmt) SQLCount() (string, []interface{}) {
 	bs := bytes.NewBufferString("select count(*) from ")
 	bs.WriteString(s.table)
 
+	for _, j := range s.joins {
+		bs.WriteString(j)
+	}
+
 	s.SQLCondition(bs)
 
-	sql := bs.String()
-	log.Debugf("sql:%v", sql)
-	return sql
+	sql := s.rewritePlaceholders(bs.String())
+	log.Debugf("sql:%v args:%v", sql, s.whereArgs)
+	return sql, s.whereArgs
 }
 
-// SQLQuery 根据条件及结构生成查询sql
-func (s *Stmt) SQLQuery(rt reflect.Type) string {
-	firstTable := strings.Split(s.table, ",")[0]
+//timeType 用于把匿名的time.Time字段跟真正的关联子结构体区分开.
+var timeType = reflect.TypeOf(time.Time{})
 
-	bs := bytes.NewBufferString("select ")
+//isJoinField 匿名结构体字段（time.Time除外）被当成一张关联表，可以通过join tag声明关联条件.
+func isJoinField(f reflect.StructField) bool {
+	return f.Anonymous && f.Type.Kind() == reflect.Struct && f.Type != timeType
+}
 
+//registerJoin 解析"table.col=other.col"形式的join tag，登记一个left join，返回关联表名.
+//joins只追加到调用方传入的切片，不改动*Stmt本身，这样同一个Stmt可以被反复用于生成sql.
+func registerJoin(on string, joins *[]string) string {
+	table := on
+	if i := strings.IndexByte(on, '.'); i > 0 {
+		table = on[:i]
+	}
+	*joins = append(*joins, fmt.Sprintf(" left join %s on %s", table, on))
+	return table
+}
+
+//sqlColumns 递归遍历rt写出"table.column"列表，遇到带join tag的匿名子结构体就登记join，
+//并用子结构体自己的表名限定它的列，从而支持struct{ User; Order }这类多表查询.
+func sqlColumns(bs *bytes.Buffer, rt reflect.Type, table string, joins *[]string) {
 	for i := 0; i < rt.NumField(); i++ {
 		f := rt.Field(i)
 		if f.PkgPath != "" && !f.Anonymous { // unexported
 			continue
 		}
+
+		if isJoinField(f) {
+			sub := table
+			if on := f.Tag.Get("join"); on != "" {
+				sub = registerJoin(on, joins)
+			}
+			sqlColumns(bs, f.Type, sub, joins)
+			continue
+		}
+
 		name := f.Tag.Get("db")
 		if name == "" {
 			name = FieldEscape(f.Name)
 		}
 		if !strings.Contains(name, ".") {
-			fmt.Fprintf(bs, "%s.", firstTable)
+			fmt.Fprintf(bs, "%s.", table)
 		}
 		fmt.Fprintf(bs, "%s, ", name)
 	}
+}
+
+// SQLQuery 根据条件及结构生成查询sql，返回sql及where中绑定的参数
+func (s *Stmt) SQLQuery(rt reflect.Type) (string, []interface{}) {
+	firstTable := strings.Split(s.table, ",")[0]
+
+	bs := bytes.NewBufferString("select ")
+
+	//joins以s.joins（Join/LeftJoin/InnerJoin显式添加的部分）为起点，tag解析出的join只追加
+	//到这份局部拷贝，避免重复调用SQLQuery时join子句不断累加到s.joins上.
+	joins := append([]string(nil), s.joins...)
+
+	sqlColumns(bs, rt, firstTable, &joins)
 
 	bs.Truncate(bs.Len() - 2)
 	fmt.Fprintf(bs, " from %s", s.table)
 
+	for _, j := range joins {
+		bs.WriteString(j)
+	}
+
 	s.SQLCondition(bs)
 
-	sql := bs.String()
-	log.Debugf("sql:%v", sql)
-	return sql
+	sql := s.rewritePlaceholders(bs.String())
+	log.Debugf("sql:%v args:%v", sql, s.whereArgs)
+	return sql, s.whereArgs
+}
+
+//collectRefs 按跟sqlColumns一致的遍历顺序递归收集可写入的字段地址，用于rows.Scan.
+func collectRefs(rv reflect.Value, rt reflect.Type) []interface{} {
+	var refs []interface{}
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" && !f.Anonymous { // unexported
+			continue
+		}
+
+		if isJoinField(f) {
+			refs = append(refs, collectRefs(rv.Field(i), f.Type)...)
+			continue
+		}
+
+		refs = append(refs, rv.Field(i).Addr().Interface())
+	}
+	return refs
 }
 
 // Query 根据传入的result结构，生成查询sql，并返回执行结果， result 必需是一个指向切片的指针.
@@ -192,9 +393,9 @@ func (s *Stmt) Query(result interface{}) error {
 		return fmt.Errorf("result not found field")
 	}
 
-	sql := s.SQLQuery(rt)
+	sql, args := s.SQLQuery(rt)
 
-	rows, err := s.db.Query(sql)
+	rows, err := s.queryContext(sql, args...)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -203,16 +404,8 @@ func (s *Stmt) Query(result interface{}) error {
 	rv := reflect.ValueOf(result).Elem()
 
 	for rows.Next() {
-		var refs []interface{}
 		obj := reflect.New(rt)
-
-		for i := 0; i < obj.Elem().NumField(); i++ {
-			f := rt.Field(i)
-			if f.PkgPath != "" && !f.Anonymous { // unexported
-				continue
-			}
-			refs = append(refs, obj.Elem().Field(i).Addr().Interface())
-		}
+		refs := collectRefs(obj.Elem(), rt)
 
 		if err = rows.Scan(refs...); err != nil {
 			return errors.Trace(err)
@@ -239,7 +432,8 @@ func (s *Stmt) Query(result interface{}) error {
 
 //Count 查询总数.
 func (s *Stmt) Count() (int64, error) {
-	rows, err := s.db.Query(s.SQLCount())
+	sql, args := s.SQLCount()
+	rows, err := s.queryContext(sql, args...)
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
@@ -258,7 +452,7 @@ func (s *Stmt) Count() (int64, error) {
 //SQLInsert 添加数据
 func (s *Stmt) SQLInsert(rt reflect.Type, rv reflect.Value) (sql string, refs []interface{}) {
 	bs := bytes.NewBufferString("insert into ")
-	bs.WriteString(s.table)
+	bs.WriteString(s.dialect.QuoteIdent(s.table))
 	bs.WriteString(" (")
 
 	dbs := bytes.NewBufferString(") values (")
@@ -276,7 +470,7 @@ func (s *Stmt) SQLInsert(rt reflect.Type, rv reflect.Value) (sql string, refs []
 			name = FieldEscape(rt.Field(i).Name)
 		}
 
-		bs.WriteString(name)
+		bs.WriteString(s.dialect.QuoteIdent(name))
 		bs.WriteString(", ")
 
 		if def != "" {
@@ -295,7 +489,7 @@ func (s *Stmt) SQLInsert(rt reflect.Type, rv reflect.Value) (sql string, refs []
 	bs.WriteString(dbs.String())
 
 	bs.WriteString(") ")
-	sql = bs.String()
+	sql = s.rewritePlaceholders(bs.String())
 	return
 }
 
@@ -322,7 +516,7 @@ func FieldEscape(k string) string {
 // SQLUpdate 根据条件及结构生成update sql
 func (s *Stmt) SQLUpdate(rt reflect.Type, rv reflect.Value) (sql string, refs []interface{}) {
 	bs := bytes.NewBufferString("")
-	fmt.Fprintf(bs, "update `%s` set ", s.table)
+	fmt.Fprintf(bs, "update %s set ", s.dialect.QuoteIdent(s.table))
 
 	for i := 0; i < rt.NumField(); i++ {
 		if rt.Field(i).PkgPath != "" && !rt.Field(i).Anonymous { // unexported
@@ -337,7 +531,7 @@ func (s *Stmt) SQLUpdate(rt reflect.Type, rv reflect.Value) (sql string, refs []
 			name = FieldEscape(rt.Field(i).Name)
 		}
 
-		fmt.Fprintf(bs, "`%s`=", name)
+		fmt.Fprintf(bs, "%s=", s.dialect.QuoteIdent(name))
 
 		if def != "" {
 			fmt.Fprintf(bs, "%s, ", def)
@@ -352,8 +546,10 @@ func (s *Stmt) SQLUpdate(rt reflect.Type, rv reflect.Value) (sql string, refs []
 
 	s.SQLCondition(bs)
 
-	sql = bs.String()
-	log.Debugf("sql:%v", sql)
+	refs = append(refs, s.whereArgs...)
+
+	sql = s.rewritePlaceholders(bs.String())
+	log.Debugf("sql:%v args:%v", sql, refs)
 	return
 }
 
@@ -371,7 +567,7 @@ func (s *Stmt) Update(data interface{}) (int64, error) {
 		return 0, fmt.Errorf("data not found field")
 	}
 	sql, refs := s.SQLUpdate(rt, rv)
-	r, err := s.db.Exec(sql, refs...)
+	r, err := s.execContext(sql, refs...)
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
@@ -393,10 +589,221 @@ func (s *Stmt) Insert(data interface{}) (int64, error) {
 	}
 
 	sql, refs := s.SQLInsert(rt, rv)
-	r, err := s.db.Exec(sql, refs...)
+	r, err := s.execContext(sql, refs...)
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
 
 	return r.LastInsertId()
 }
+
+//insertColumns 返回SQLInsert会写入的列名，顺序与SQLInsert一致，跳过db_default:"auto"的自增列.
+func insertColumns(rt reflect.Type) []string {
+	var cols []string
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" && !f.Anonymous { // unexported
+			continue
+		}
+		if f.Tag.Get("db_default") == "auto" {
+			continue
+		}
+		name := f.Tag.Get("db")
+		if name == "" {
+			name = FieldEscape(f.Name)
+		}
+		cols = append(cols, name)
+	}
+	return cols
+}
+
+//autoColumns 返回rt中db_default:"auto"标注的列，约定这类列是自增主键或唯一键，
+//SQLUpsert在没有显式OnConflict时用它作为on conflict的冲突目标列.
+func autoColumns(rt reflect.Type) []string {
+	var cols []string
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" && !f.Anonymous { // unexported
+			continue
+		}
+		if f.Tag.Get("db_default") != "auto" {
+			continue
+		}
+		name := f.Tag.Get("db")
+		if name == "" {
+			name = FieldEscape(f.Name)
+		}
+		cols = append(cols, name)
+	}
+	return cols
+}
+
+//SQLInsertBatch 把rvs中的每一行按SQLInsert同样的列规则（跳过db_default:"auto"，
+//db_default:"NOW()"之类的表达式直接内联）拼成一条insert into t (cols...) values (...),(...)...
+func (s *Stmt) SQLInsertBatch(rt reflect.Type, rvs []reflect.Value) (sql string, refs []interface{}) {
+	type field struct {
+		index int
+		def   string
+	}
+
+	var fields []field
+	cols := bytes.NewBufferString("insert into ")
+	cols.WriteString(s.dialect.QuoteIdent(s.table))
+	cols.WriteString(" (")
+
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" && !f.Anonymous { // unexported
+			continue
+		}
+		def := f.Tag.Get("db_default")
+		if def == "auto" {
+			continue
+		}
+		name := f.Tag.Get("db")
+		if name == "" {
+			name = FieldEscape(f.Name)
+		}
+
+		cols.WriteString(s.dialect.QuoteIdent(name))
+		cols.WriteString(", ")
+		fields = append(fields, field{index: i, def: def})
+	}
+	cols.Truncate(cols.Len() - 2)
+	cols.WriteString(") values ")
+
+	for ri, rv := range rvs {
+		if ri > 0 {
+			cols.WriteString(", ")
+		}
+		cols.WriteString("(")
+		for i, f := range fields {
+			if i > 0 {
+				cols.WriteString(", ")
+			}
+			if f.def != "" {
+				cols.WriteString(f.def)
+				continue
+			}
+			cols.WriteString("?")
+			refs = append(refs, rv.Field(f.index).Interface())
+		}
+		cols.WriteString(")")
+	}
+
+	sql = s.rewritePlaceholders(cols.String())
+	log.Debugf("sql:%v args:%v", sql, refs)
+	return
+}
+
+//InsertBatch 把slice中的结构体用一条insert语句批量写入，只有一次到数据库的往返，
+//比逐行调用Insert快得多，常用于导入类任务.
+func (s *Stmt) InsertBatch(slice interface{}) (int64, error) {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice {
+		return 0, fmt.Errorf("slice type must be slice, recv:%v", rv.Kind())
+	}
+
+	n := rv.Len()
+	if n == 0 {
+		return 0, nil
+	}
+
+	rt := rv.Type().Elem()
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.NumField() == 0 {
+		return 0, fmt.Errorf("data not found field")
+	}
+
+	rows := make([]reflect.Value, n)
+	for i := 0; i < n; i++ {
+		item := rv.Index(i)
+		if item.Kind() == reflect.Ptr {
+			item = item.Elem()
+		}
+		rows[i] = item
+	}
+
+	sql, refs := s.SQLInsertBatch(rt, rows)
+	r, err := s.execContext(sql, refs...)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	return r.RowsAffected()
+}
+
+//SQLUpsert 在SQLInsert的基础上追加dialect.UpsertClause生成的冲突更新子句，
+//updateCols为空时更新所有非自增列；冲突判断列优先用OnConflict显式指定的，
+//没有指定时退回db_default:"auto"标注的列.
+func (s *Stmt) SQLUpsert(rt reflect.Type, rv reflect.Value, updateCols []string) (sql string, refs []interface{}) {
+	sql, refs = s.SQLInsert(rt, rv)
+
+	cols := updateCols
+	if len(cols) == 0 {
+		cols = insertColumns(rt)
+	}
+
+	conflictCols := s.conflictCols
+	if len(conflictCols) == 0 {
+		conflictCols = autoColumns(rt)
+	}
+
+	sql = sql + " " + s.dialect.UpsertClause(conflictCols, cols)
+	log.Debugf("sql:%v args:%v", sql, refs)
+	return
+}
+
+//Upsert 插入一行数据，遇到唯一键冲突时更新updateCols指定的列（为空则更新所有非自增列）.
+func (s *Stmt) Upsert(data interface{}, updateCols ...string) (int64, error) {
+	rt := reflect.TypeOf(data)
+	rv := reflect.ValueOf(data)
+
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+		rv = rv.Elem()
+	}
+
+	if rt.NumField() == 0 {
+		return 0, fmt.Errorf("data not found field")
+	}
+
+	if s.dialect.NeedsConflictTarget() && len(s.conflictCols) == 0 && len(autoColumns(rt)) == 0 {
+		return 0, fmt.Errorf("%s: upsert needs a conflict target, call OnConflict or tag a db_default:\"auto\" field", s.table)
+	}
+
+	sql, refs := s.SQLUpsert(rt, rv, updateCols)
+	r, err := s.execContext(sql, refs...)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	return r.RowsAffected()
+}
+
+//SQLDelete 根据条件生成delete sql
+func (s *Stmt) SQLDelete() (string, []interface{}) {
+	bs := bytes.NewBufferString("delete from ")
+	bs.WriteString(s.dialect.QuoteIdent(s.table))
+
+	s.SQLCondition(bs)
+
+	sql := s.rewritePlaceholders(bs.String())
+	log.Debugf("sql:%v args:%v", sql, s.whereArgs)
+	return sql, s.whereArgs
+}
+
+//Delete 根据where条件删除数据.
+func (s *Stmt) Delete() (int64, error) {
+	sql, args := s.SQLDelete()
+	r, err := s.execContext(sql, args...)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return r.RowsAffected()
+}
@@ -0,0 +1,17 @@
+package orm
+
+import "testing"
+
+func TestNewStmtTxWithDialect(t *testing.T) {
+	s := NewStmtTxWithDialect(nil, "users", PostgresDialect)
+
+	if s.dialect != PostgresDialect {
+		t.Errorf("dialect=%v want=PostgresDialect", s.dialect)
+	}
+
+	got := s.rewritePlaceholders("select * from users where id=? and name=?")
+	want := "select * from users where id=$1 and name=$2"
+	if got != want {
+		t.Errorf("rewritePlaceholders=%q want=%q", got, want)
+	}
+}